@@ -0,0 +1,110 @@
+package GoMNIST
+
+import (
+	"iter"
+	"math/rand"
+)
+
+// Batch is a contiguous slice of samples ready for a training step.
+type Batch struct {
+	Images [][]float64
+	Labels [][]float64
+}
+
+// Dataset bundles a set of MNIST images together with their float64, label
+// and one-hot label representations, and provides the iteration helpers a
+// training loop typically needs on top of the raw slices returned by
+// ReadImageFile and ReadLabelFile.
+type Dataset struct {
+	Rows, Cols   int
+	Images       []RawImage
+	ImagesFloat  [][]float64
+	ImagesNorm   [][]float64
+	Labels       []Label
+	LabelsOneHot [][]float64
+}
+
+// NewDataset bundles the parallel slices returned by ReadImageFile and
+// ReadLabelFile into a Dataset. It does not copy the slices.
+func NewDataset(rows, cols int, imgs []RawImage, imgsFloat, imgsNorm [][]float64, labels []Label, labelsOneHot [][]float64) *Dataset {
+	return &Dataset{
+		Rows:         rows,
+		Cols:         cols,
+		Images:       imgs,
+		ImagesFloat:  imgsFloat,
+		ImagesNorm:   imgsNorm,
+		Labels:       labels,
+		LabelsOneHot: labelsOneHot,
+	}
+}
+
+// Len returns the number of samples in the dataset.
+func (d *Dataset) Len() int {
+	return len(d.Images)
+}
+
+// Get returns the image and label of the i'th sample.
+func (d *Dataset) Get(i int) (img RawImage, label Label) {
+	return d.Images[i], d.Labels[i]
+}
+
+// Shuffle randomly permutes the samples in place using rng.
+func (d *Dataset) Shuffle(rng *rand.Rand) {
+	perm := rng.Perm(d.Len())
+	d.Images = permute(d.Images, perm)
+	d.ImagesFloat = permute(d.ImagesFloat, perm)
+	d.ImagesNorm = permute(d.ImagesNorm, perm)
+	d.Labels = permute(d.Labels, perm)
+	d.LabelsOneHot = permute(d.LabelsOneHot, perm)
+}
+
+// Split divides the dataset into two: the first containing the leading
+// fraction ratio of samples (rounded down), the second the remainder.
+// ratio must be in [0, 1]. Callers typically Shuffle beforehand so the
+// split isn't biased by the on-disk sample order.
+func (d *Dataset) Split(ratio float64) (train, val *Dataset) {
+	n := int(float64(d.Len()) * ratio)
+	train = &Dataset{
+		Rows: d.Rows, Cols: d.Cols,
+		Images: d.Images[:n], ImagesFloat: d.ImagesFloat[:n], ImagesNorm: d.ImagesNorm[:n],
+		Labels: d.Labels[:n], LabelsOneHot: d.LabelsOneHot[:n],
+	}
+	val = &Dataset{
+		Rows: d.Rows, Cols: d.Cols,
+		Images: d.Images[n:], ImagesFloat: d.ImagesFloat[n:], ImagesNorm: d.ImagesNorm[n:],
+		Labels: d.Labels[n:], LabelsOneHot: d.LabelsOneHot[n:],
+	}
+	return train, val
+}
+
+// Batches returns an iterator over contiguous mini-batches of size size,
+// using the normalized ([0,1]) image representation and one-hot labels.
+// The final batch is short if Len() is not a multiple of size. Batches
+// yields nothing if size <= 0.
+func (d *Dataset) Batches(size int) iter.Seq[*Batch] {
+	return func(yield func(*Batch) bool) {
+		if size <= 0 {
+			return
+		}
+		for start := 0; start < d.Len(); start += size {
+			end := min(start+size, d.Len())
+			b := &Batch{
+				Images: d.ImagesNorm[start:end],
+				Labels: d.LabelsOneHot[start:end],
+			}
+			if !yield(b) {
+				return
+			}
+		}
+	}
+}
+
+// permute returns a new slice containing s reordered according to perm,
+// where perm is a permutation of [0, len(s)) as produced by rand.Perm.
+func permute[T any](s []T, perm []int) []T {
+	out := make([]T, len(s))
+	for i, p := range perm {
+		out[i] = s[p]
+	}
+	return out
+}
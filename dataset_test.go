@@ -0,0 +1,53 @@
+package GoMNIST
+
+import "testing"
+
+func testDataset(n int) *Dataset {
+	imgs := make([]RawImage, n)
+	imgsFloat := make([][]float64, n)
+	imgsNorm := make([][]float64, n)
+	labels := make([]Label, n)
+	labelsOneHot := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		imgs[i] = RawImage{byte(i)}
+		imgsFloat[i] = []float64{float64(i)}
+		imgsNorm[i] = []float64{float64(i) / 255.0}
+		labels[i] = Label(i % 10)
+		labelsOneHot[i] = make([]float64, 10)
+		labelsOneHot[i][i%10] = 1
+	}
+	return NewDataset(1, 1, imgs, imgsFloat, imgsNorm, labels, labelsOneHot)
+}
+
+func TestBatchesInvalidSizeYieldsNothing(t *testing.T) {
+	d := testDataset(5)
+	for _, size := range []int{0, -1} {
+		count := 0
+		for range d.Batches(size) {
+			count++
+			if count > 1 {
+				t.Fatalf("Batches(%d) did not terminate", size)
+			}
+		}
+		if count != 0 {
+			t.Errorf("Batches(%d) yielded %d batches, want 0", size, count)
+		}
+	}
+}
+
+func TestBatchesShortFinalBatch(t *testing.T) {
+	d := testDataset(5)
+	var sizes []int
+	for b := range d.Batches(2) {
+		sizes = append(sizes, len(b.Images))
+	}
+	want := []int{2, 2, 1}
+	if len(sizes) != len(want) {
+		t.Fatalf("got %v, want %v", sizes, want)
+	}
+	for i := range want {
+		if sizes[i] != want[i] {
+			t.Fatalf("got %v, want %v", sizes, want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package GoMNIST
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Source describes a set of four gzipped IDX files (train images, train
+// labels, test images, test labels) sharing the MNIST format, together
+// with their SHA-256 checksums, so that drop-in replacements such as
+// Fashion-MNIST or KMNIST can be fetched and parsed the same way as the
+// original MNIST.
+type Source struct {
+	Name string
+	URLs [4]string
+	Sums [4]string
+}
+
+// file indices within a Source's URLs/Sums arrays.
+const (
+	trainImages = iota
+	trainLabels
+	testImages
+	testLabels
+)
+
+var (
+	// MNIST is the original MNIST handwritten digit dataset.
+	MNIST = Source{
+		Name: "mnist",
+		URLs: [4]string{
+			"https://storage.googleapis.com/cvdf-datasets/mnist/train-images-idx3-ubyte.gz",
+			"https://storage.googleapis.com/cvdf-datasets/mnist/train-labels-idx1-ubyte.gz",
+			"https://storage.googleapis.com/cvdf-datasets/mnist/t10k-images-idx3-ubyte.gz",
+			"https://storage.googleapis.com/cvdf-datasets/mnist/t10k-labels-idx1-ubyte.gz",
+		},
+		Sums: [4]string{
+			"440fcabf73cc546fa21475e81ea370265605f56be210a4024d2ca8f203523b02",
+			"3552534a0a558bbed6aed32b30c495fcaffc749d6d9f145d06fc8f86cbc61a0c",
+			"8d422c7b0a1c1c79245a5bcf07fe86e33eeafee792b84584aec276f5a2dbc4e2",
+			"f7ae60f92e00ec6debd23a6088c31dbd2371ecefb9d20ffcd5a3b6af2c892bfb",
+		},
+	}
+
+	// FashionMNIST is Zalando's drop-in replacement for MNIST.
+	//
+	// Sums is intentionally left blank: no independently verified SHA-256
+	// digest for these mirrors was available at the time this source was
+	// added. Download skips checksum verification when a Sum is empty, so
+	// files are still fetched and cached, just without integrity checking
+	// until real digests are sourced.
+	FashionMNIST = Source{
+		Name: "fashion-mnist",
+		URLs: [4]string{
+			"http://fashion-mnist.s3-website.eu-central-1.amazonaws.com/train-images-idx3-ubyte.gz",
+			"http://fashion-mnist.s3-website.eu-central-1.amazonaws.com/train-labels-idx1-ubyte.gz",
+			"http://fashion-mnist.s3-website.eu-central-1.amazonaws.com/t10k-images-idx3-ubyte.gz",
+			"http://fashion-mnist.s3-website.eu-central-1.amazonaws.com/t10k-labels-idx1-ubyte.gz",
+		},
+	}
+
+	// KMNIST is the Kuzushiji-MNIST dataset of cursive Japanese characters.
+	//
+	// Sums is intentionally left blank; see the comment on FashionMNIST.Sums.
+	KMNIST = Source{
+		Name: "kmnist",
+		URLs: [4]string{
+			"http://codh.rois.ac.jp/kmnist/dataset/kmnist/train-images-idx3-ubyte.gz",
+			"http://codh.rois.ac.jp/kmnist/dataset/kmnist/train-labels-idx1-ubyte.gz",
+			"http://codh.rois.ac.jp/kmnist/dataset/kmnist/t10k-images-idx3-ubyte.gz",
+			"http://codh.rois.ac.jp/kmnist/dataset/kmnist/t10k-labels-idx1-ubyte.gz",
+		},
+	}
+)
+
+// CacheDir returns the directory GoMNIST caches downloaded dataset files
+// in, honoring XDG_CACHE_HOME when set.
+func CacheDir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "gomnist"), nil
+}
+
+// Download fetches the four gzipped IDX files of src into dir, verifying
+// each against its expected SHA-256 checksum when src.Sums has one. Files
+// already present in dir with a matching checksum are not re-downloaded;
+// for a file whose Sum is empty, any existing copy is kept unverified.
+func Download(dir string, src Source) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i, url := range src.URLs {
+		path := filepath.Join(dir, filepath.Base(url))
+		if cached(path, src.Sums[i]) {
+			continue
+		}
+		if err := downloadFile(path, url, src.Sums[i]); err != nil {
+			return fmt.Errorf("download %s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+// cached reports whether path already holds a usable copy of a source
+// file: if sum is set, that means matching its checksum; otherwise, since
+// there's nothing to verify against, simply existing.
+func cached(path, sum string) bool {
+	if sum == "" {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+	ok, _ := hasSum(path, sum)
+	return ok
+}
+
+// Load ensures src's files are present in dir (downloading them via
+// Download if necessary) and parses them into train and test Datasets.
+func Load(dir string, src Source) (train, test *Dataset, err error) {
+	if err = Download(dir, src); err != nil {
+		return nil, nil, err
+	}
+	names := [4]string{}
+	for i, url := range src.URLs {
+		names[i] = filepath.Join(dir, filepath.Base(url))
+	}
+	train, err = loadSplit(names[trainImages], names[trainLabels])
+	if err != nil {
+		return nil, nil, err
+	}
+	test, err = loadSplit(names[testImages], names[testLabels])
+	if err != nil {
+		return nil, nil, err
+	}
+	return train, test, nil
+}
+
+func loadSplit(imageFile, labelFile string) (*Dataset, error) {
+	rows, cols, imgs, imgsFloat, imgsNorm, err := ReadImageFile(imageFile)
+	if err != nil {
+		return nil, err
+	}
+	labels, labelsOneHot, err := ReadLabelFile(labelFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewDataset(rows, cols, imgs, imgsFloat, imgsNorm, labels, labelsOneHot), nil
+}
+
+func downloadFile(path, url, sum string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), resp.Body); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	f.Close()
+	if got := hex.EncodeToString(h.Sum(nil)); sum != "" && got != sum {
+		os.Remove(tmp)
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, sum)
+	}
+	return os.Rename(tmp, path)
+}
+
+func hasSum(path, sum string) (bool, error) {
+	if sum == "" {
+		return false, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) == sum, nil
+}
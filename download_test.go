@@ -0,0 +1,51 @@
+package GoMNIST
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSourceSumsWellFormed guards against the checksums in a Source being
+// silently truncated: a non-empty Sum must be a full 64-character SHA-256
+// hex digest, or downloadFile's comparison against a freshly computed
+// digest (always 64 chars) can never succeed.
+func TestSourceSumsWellFormed(t *testing.T) {
+	for _, src := range []Source{MNIST, FashionMNIST, KMNIST} {
+		for i, sum := range src.Sums {
+			if sum == "" {
+				continue
+			}
+			if len(sum) != 64 {
+				t.Errorf("%s: Sums[%d] has length %d, want 64 (or empty): %q", src.Name, i, len(sum), sum)
+			}
+		}
+	}
+}
+
+// TestMNISTSumsPresent ensures the flagship MNIST source ships checksums
+// for all four files, since Download/Load for it is expected to verify
+// integrity rather than silently skip it.
+func TestMNISTSumsPresent(t *testing.T) {
+	for i, sum := range MNIST.Sums {
+		if sum == "" {
+			t.Errorf("MNIST.Sums[%d] is empty, want a SHA-256 digest", i)
+		}
+	}
+}
+
+// TestCachedNoSum ensures a file that's already on disk is treated as
+// cached even when there's no checksum to verify it against, as is the
+// case for sources like FashionMNIST and KMNIST.
+func TestCachedNoSum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	if cached(path, "") {
+		t.Fatal("cached() = true for a file that doesn't exist yet")
+	}
+	if err := os.WriteFile(path, []byte("data"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if !cached(path, "") {
+		t.Fatal("cached() = false for an existing file with no checksum to verify")
+	}
+}
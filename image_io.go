@@ -0,0 +1,70 @@
+package GoMNIST
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+
+	"golang.org/x/image/bmp"
+)
+
+// EncodePNG writes img to w as a PNG.
+func (img RawImage) EncodePNG(w io.Writer) error {
+	return png.Encode(w, img)
+}
+
+// EncodeBMP writes img to w as a BMP.
+func (img RawImage) EncodeBMP(w io.Writer) error {
+	return bmp.Encode(w, img)
+}
+
+// SaveGrid lays out imgs in a grid with the given number of columns and
+// writes the result to path as a PNG. The number of rows is derived from
+// len(imgs) and cols; any cells left over in the last row stay black.
+func SaveGrid(path string, imgs []RawImage, cols int) error {
+	if cols <= 0 {
+		cols = 1
+	}
+	rows := (len(imgs) + cols - 1) / cols
+	grid := image.NewGray(image.Rect(0, 0, cols*Width, rows*Height))
+	for i, img := range imgs {
+		ox := (i % cols) * Width
+		oy := (i / cols) * Height
+		for y := 0; y < Height; y++ {
+			for x := 0; x < Width; x++ {
+				grid.SetGray(ox+x, oy+y, color.Gray{Y: img[y*Width+x]})
+			}
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, grid)
+}
+
+// LoadPNGAsRawImage reads a 28x28 grayscale PNG from path and converts it
+// to a RawImage, for round-tripping images saved with EncodePNG/SaveGrid.
+func LoadPNGAsRawImage(path string) (RawImage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	src, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	b := src.Bounds()
+	img := make(RawImage, Width*Height)
+	for y := 0; y < Height && y < b.Dy(); y++ {
+		for x := 0; x < Width && x < b.Dx(); x++ {
+			g := color.GrayModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			img[y*Width+x] = g.Y
+		}
+	}
+	return img, nil
+}
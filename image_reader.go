@@ -0,0 +1,199 @@
+package GoMNIST
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+)
+
+// ImageReader streams images one at a time from an IDX image file,
+// avoiding the need to hold the whole dataset in memory at once.
+type ImageReader struct {
+	r          io.Reader
+	closer     io.Closer
+	Rows, Cols int
+	N          int
+	read       int
+}
+
+// NewImageReader opens the named gzipped IDX image file and reads its
+// header, returning a reader ready to yield images via Next.
+func NewImageReader(name string) (*ImageReader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	z, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	ir, err := newImageReader(z)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	ir.closer = f
+	return ir, nil
+}
+
+func newImageReader(r io.Reader) (*ImageReader, error) {
+	var magic, n, nrow, ncol int32
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil {
+		return nil, err
+	}
+	if magic != imageMagic {
+		return nil, os.ErrInvalid
+	}
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &nrow); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &ncol); err != nil {
+		return nil, err
+	}
+	return &ImageReader{r: r, Rows: int(nrow), Cols: int(ncol), N: int(n)}, nil
+}
+
+// Next returns the next image in the file. It returns io.EOF once all N
+// images have been read.
+func (ir *ImageReader) Next() (RawImage, error) {
+	if ir.read >= ir.N {
+		return nil, io.EOF
+	}
+	img := make(RawImage, ir.Rows*ir.Cols)
+	if _, err := io.ReadFull(ir.r, img); err != nil {
+		return nil, err
+	}
+	ir.read++
+	return img, nil
+}
+
+// Close releases the underlying file, if any.
+func (ir *ImageReader) Close() error {
+	if ir.closer == nil {
+		return nil
+	}
+	return ir.closer.Close()
+}
+
+// LabelReader streams labels one at a time from an IDX label file.
+type LabelReader struct {
+	r      io.Reader
+	closer io.Closer
+	N      int
+	read   int
+}
+
+// NewLabelReader opens the named gzipped IDX label file and reads its
+// header, returning a reader ready to yield labels via Next.
+func NewLabelReader(name string) (*LabelReader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	z, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	lr, err := newLabelReader(z)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	lr.closer = f
+	return lr, nil
+}
+
+func newLabelReader(r io.Reader) (*LabelReader, error) {
+	var magic, n int32
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil {
+		return nil, err
+	}
+	if magic != labelMagic {
+		return nil, os.ErrInvalid
+	}
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	return &LabelReader{r: r, N: int(n)}, nil
+}
+
+// Next returns the next label in the file. It returns io.EOF once all N
+// labels have been read.
+func (lr *LabelReader) Next() (Label, error) {
+	if lr.read >= lr.N {
+		return 0, io.EOF
+	}
+	var l Label
+	if err := binary.Read(lr.r, binary.BigEndian, &l); err != nil {
+		return 0, err
+	}
+	lr.read++
+	return l, nil
+}
+
+// Close releases the underlying file, if any.
+func (lr *LabelReader) Close() error {
+	if lr.closer == nil {
+		return nil
+	}
+	return lr.closer.Close()
+}
+
+// Sample is one (image, label) pair yielded by a SampleIterator.
+type Sample struct {
+	Image RawImage
+	Label Label
+}
+
+// SampleIterator pairs up an ImageReader and a LabelReader so image and
+// label files can be consumed together, one sample at a time, in constant
+// memory.
+type SampleIterator struct {
+	images *ImageReader
+	labels *LabelReader
+}
+
+// NewSampleIterator opens the named image and label files and returns an
+// iterator over their paired samples.
+func NewSampleIterator(imageFile, labelFile string) (*SampleIterator, error) {
+	images, err := NewImageReader(imageFile)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := NewLabelReader(labelFile)
+	if err != nil {
+		images.Close()
+		return nil, err
+	}
+	return &SampleIterator{images: images, labels: labels}, nil
+}
+
+// Next returns the next (image, label) pair. It returns io.EOF once the
+// shorter of the two underlying files is exhausted.
+func (si *SampleIterator) Next() (Sample, error) {
+	img, err := si.images.Next()
+	if err != nil {
+		return Sample{}, err
+	}
+	label, err := si.labels.Next()
+	if err != nil {
+		return Sample{}, err
+	}
+	return Sample{Image: img, Label: label}, nil
+}
+
+// Close releases the underlying image and label files.
+func (si *SampleIterator) Close() error {
+	ierr := si.images.Close()
+	lerr := si.labels.Close()
+	if ierr != nil {
+		return ierr
+	}
+	return lerr
+}
@@ -51,63 +51,25 @@ func (img RawImage) At(x, y int) color.Color {
 
 // ReadImageFile opens the named image file (training or test), parses it and
 // returns all images in order.
+//
+// It is a thin wrapper around Read for callers who want the original
+// unnormalized and normalized float64 representations together; new code
+// that only needs one representation should call Read directly with the
+// relevant Option.
 func ReadImageFile(name string) (rows, cols int, imgs []RawImage, imgsfloat [][]float64, imgsfloatNorm [][]float64, err error) {
-	f, err := os.Open(name)
-	if err != nil {
-		return 0, 0, nil, nil, nil, err
-	}
-	defer f.Close()
-	z, err := gzip.NewReader(f)
+	images, err := Read(name, WithRaw(), WithFloat64(), WithNormalize(NormalizeNone))
 	if err != nil {
 		return 0, 0, nil, nil, nil, err
 	}
-	return readImageFile(z)
-}
-
-func readImageFile(r io.Reader) (rows, cols int, imgs []RawImage, imgsfloat [][]float64, imgsfloatNorm [][]float64, err error) {
-	var (
-		magic int32
-		n     int32
-		nrow  int32
-		ncol  int32
-	)
-	if err = binary.Read(r, binary.BigEndian, &magic); err != nil {
-		return 0, 0, nil, nil, nil, err
-	}
-	if magic != imageMagic {
-		return 0, 0, nil, nil, nil, os.ErrInvalid
-	}
-	if err = binary.Read(r, binary.BigEndian, &n); err != nil {
-		return 0, 0, nil, nil, nil, err
-	}
-	if err = binary.Read(r, binary.BigEndian, &nrow); err != nil {
-		return 0, 0, nil, nil, nil, err
-
-	}
-	if err = binary.Read(r, binary.BigEndian, &ncol); err != nil {
-		return 0, 0, nil, nil, nil, err
-	}
-	imgs = make([]RawImage, n)
-	imgsfloat = make([][]float64, n)
-	imgsfloatNorm = make([][]float64, n)
-	m := int(nrow * ncol)
-	for i := 0; i < int(n); i++ {
-		imgs[i] = make(RawImage, m)
-		imgsfloat[i] = make([]float64, m)
-		imgsfloatNorm[i] = make([]float64, m)
-		m_, err := io.ReadFull(r, imgs[i])
-		for j := 0; j < m; j++ {
-			imgsfloat[i][j] = float64(imgs[i][j])
-			imgsfloatNorm[i][j] = float64(imgs[i][j]) / 255.0
-		}
-		if err != nil {
-			return 0, 0, nil, nil, nil, err
-		}
-		if m_ != int(m) {
-			return 0, 0, nil, nil, nil, os.ErrInvalid
+	imgsfloatNorm = make([][]float64, len(images.Float64))
+	for i, f := range images.Float64 {
+		row := make([]float64, len(f))
+		for j, v := range f {
+			row[j] = v / 255.0
 		}
+		imgsfloatNorm[i] = row
 	}
-	return int(nrow), int(ncol), imgs, imgsfloat, imgsfloatNorm, nil
+	return images.Rows, images.Cols, images.Raw, images.Float64, imgsfloatNorm, nil
 }
 
 // Label is a digit label in 0 to 9
@@ -0,0 +1,205 @@
+package GoMNIST
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+)
+
+// NormalizeMode selects how pixel values are scaled when producing
+// floating point representations of an image.
+type NormalizeMode int
+
+const (
+	// NormalizeNone leaves pixel values in [0, 255].
+	NormalizeNone NormalizeMode = iota
+	// NormalizeUnit scales pixel values to [0, 1].
+	NormalizeUnit
+	// NormalizeMeanStd scales to [0, 1] and then standardizes to
+	// zero-mean/unit-variance using MeanStd.
+	NormalizeMeanStd
+)
+
+// MNISTMean and MNISTStd are the commonly used mean and standard
+// deviation of pixel values (scaled to [0, 1]) in the MNIST training
+// set, for use with WithMeanStd.
+const (
+	MNISTMean = 0.1307
+	MNISTStd  = 0.3081
+)
+
+// Options controls which representations Read produces for each image,
+// and how they are normalized and padded. The zero value produces no
+// representations; use Read's defaults or the With* options to select
+// what's needed.
+type Options struct {
+	Raw       bool
+	Float32   bool
+	Float64   bool
+	Normalize NormalizeMode
+	Pad       int
+	MeanStd   *[2]float64
+}
+
+// Option configures an Options value.
+type Option func(*Options)
+
+// WithRaw enables the raw byte (RawImage) representation.
+func WithRaw() Option { return func(o *Options) { o.Raw = true } }
+
+// WithFloat32 enables the float32 representation.
+func WithFloat32() Option { return func(o *Options) { o.Float32 = true } }
+
+// WithFloat64 enables the float64 representation.
+func WithFloat64() Option { return func(o *Options) { o.Float64 = true } }
+
+// WithNormalize sets how the float32/float64 representations are scaled.
+func WithNormalize(mode NormalizeMode) Option {
+	return func(o *Options) { o.Normalize = mode }
+}
+
+// WithMeanStd sets NormalizeMeanStd with the given mean and standard
+// deviation, applied after scaling pixels to [0, 1].
+func WithMeanStd(mean, std float64) Option {
+	return func(o *Options) {
+		o.MeanStd = &[2]float64{mean, std}
+		o.Normalize = NormalizeMeanStd
+	}
+}
+
+// WithPad pads each image with n pixels of background (zero) on every
+// side, e.g. WithPad(2) turns 28x28 images into 32x32 for LeNet-style
+// architectures.
+func WithPad(n int) Option { return func(o *Options) { o.Pad = n } }
+
+// Images holds whichever representations of a decoded image set Read was
+// asked to produce; fields left disabled by Options are nil.
+type Images struct {
+	Rows, Cols int
+	Raw        []RawImage
+	Float32    [][]float32
+	Float64    [][]float64
+}
+
+// Read opens the named gzipped IDX image file and decodes it according to
+// opts. By default it produces the raw byte representation and
+// [0,1]-normalized float64 values, matching ReadImageFile's normalized
+// output.
+func Read(name string, opts ...Option) (*Images, error) {
+	var o Options
+	if len(opts) == 0 {
+		o = Options{Raw: true, Float64: true, Normalize: NormalizeUnit}
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	z, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return readImages(z, o)
+}
+
+func readImages(r io.Reader, o Options) (*Images, error) {
+	var magic, n, nrow, ncol int32
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil {
+		return nil, err
+	}
+	if magic != imageMagic {
+		return nil, os.ErrInvalid
+	}
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &nrow); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &ncol); err != nil {
+		return nil, err
+	}
+
+	outRows, outCols := int(nrow)+2*o.Pad, int(ncol)+2*o.Pad
+	m := int(nrow * ncol)
+	images := &Images{Rows: outRows, Cols: outCols}
+	if o.Raw {
+		images.Raw = make([]RawImage, n)
+	}
+	if o.Float32 {
+		images.Float32 = make([][]float32, n)
+	}
+	if o.Float64 {
+		images.Float64 = make([][]float64, n)
+	}
+
+	buf := make([]byte, m)
+	for i := 0; i < int(n); i++ {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		padded := pad(buf, int(nrow), int(ncol), o.Pad)
+		if o.Raw {
+			images.Raw[i] = padded
+		}
+		if o.Float32 {
+			images.Float32[i] = toFloat32(padded, o)
+		}
+		if o.Float64 {
+			images.Float64[i] = toFloat64(padded, o)
+		}
+	}
+	return images, nil
+}
+
+// pad returns buf (rows x cols) surrounded by n pixels of background on
+// every side. If n is 0, it returns a copy of buf unchanged.
+func pad(buf []byte, rows, cols, n int) []byte {
+	if n == 0 {
+		out := make([]byte, len(buf))
+		copy(out, buf)
+		return out
+	}
+	outCols := cols + 2*n
+	out := make([]byte, (rows+2*n)*outCols)
+	for y := 0; y < rows; y++ {
+		copy(out[(y+n)*outCols+n:(y+n)*outCols+n+cols], buf[y*cols:(y+1)*cols])
+	}
+	return out
+}
+
+func normalize(v byte, o Options) float64 {
+	x := float64(v)
+	if o.Normalize == NormalizeNone {
+		return x
+	}
+	x /= 255.0
+	if o.Normalize == NormalizeMeanStd {
+		mean, std := MNISTMean, MNISTStd
+		if o.MeanStd != nil {
+			mean, std = o.MeanStd[0], o.MeanStd[1]
+		}
+		x = (x - mean) / std
+	}
+	return x
+}
+
+func toFloat64(buf []byte, o Options) []float64 {
+	out := make([]float64, len(buf))
+	for i, v := range buf {
+		out[i] = normalize(v, o)
+	}
+	return out
+}
+
+func toFloat32(buf []byte, o Options) []float32 {
+	out := make([]float32, len(buf))
+	for i, v := range buf {
+		out[i] = float32(normalize(v, o))
+	}
+	return out
+}
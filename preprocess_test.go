@@ -0,0 +1,148 @@
+package GoMNIST
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeIDXImageFile writes a minimal gzipped IDX image file containing a
+// single rows x cols image with the given pixel values, for use as test
+// fixture input to Read.
+func writeIDXImageFile(t *testing.T, pixels []byte, rows, cols int32) string {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, v := range []int32{imageMagic, 1, rows, cols} {
+		if err := binary.Write(&buf, binary.BigEndian, v); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+	}
+	buf.Write(pixels)
+
+	path := filepath.Join(t.TempDir(), "images.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer f.Close()
+	z := gzip.NewWriter(f)
+	if _, err := z.Write(buf.Bytes()); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := z.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return path
+}
+
+func TestReadNormalize(t *testing.T) {
+	path := writeIDXImageFile(t, []byte{0, 127, 255, 64}, 2, 2)
+
+	t.Run("none", func(t *testing.T) {
+		images, err := Read(path, WithFloat64(), WithNormalize(NormalizeNone))
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		want := []float64{0, 127, 255, 64}
+		if !float64sEqual(images.Float64[0], want) {
+			t.Errorf("got %v, want %v", images.Float64[0], want)
+		}
+	})
+
+	t.Run("unit", func(t *testing.T) {
+		images, err := Read(path, WithFloat64(), WithNormalize(NormalizeUnit))
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		want := []float64{0, 127.0 / 255.0, 1, 64.0 / 255.0}
+		if !float64sEqual(images.Float64[0], want) {
+			t.Errorf("got %v, want %v", images.Float64[0], want)
+		}
+	})
+
+	t.Run("mean/std", func(t *testing.T) {
+		images, err := Read(path, WithFloat64(), WithMeanStd(0.5, 0.5))
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		want := []float64{
+			(0.0 - 0.5) / 0.5,
+			(127.0/255.0 - 0.5) / 0.5,
+			(1.0 - 0.5) / 0.5,
+			(64.0/255.0 - 0.5) / 0.5,
+		}
+		if !float64sApproxEqual(images.Float64[0], want, 1e-9) {
+			t.Errorf("got %v, want %v", images.Float64[0], want)
+		}
+	})
+}
+
+func TestReadFloat32(t *testing.T) {
+	path := writeIDXImageFile(t, []byte{0, 255}, 1, 2)
+	images, err := Read(path, WithFloat32(), WithNormalize(NormalizeUnit))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if images.Raw != nil {
+		t.Errorf("Raw populated though WithRaw wasn't requested")
+	}
+	want := []float32{0, 1}
+	got := images.Float32[0]
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReadPad(t *testing.T) {
+	path := writeIDXImageFile(t, []byte{1, 2, 3, 4}, 2, 2)
+	images, err := Read(path, WithRaw(), WithPad(1))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if images.Rows != 4 || images.Cols != 4 {
+		t.Fatalf("got %dx%d, want 4x4", images.Rows, images.Cols)
+	}
+	want := RawImage{
+		0, 0, 0, 0,
+		0, 1, 2, 0,
+		0, 3, 4, 0,
+		0, 0, 0, 0,
+	}
+	got := images.Raw[0]
+	if len(got) != len(want) {
+		t.Fatalf("got len %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pixel %d: got %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func float64sEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func float64sApproxEqual(a, b []float64, eps float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		d := a[i] - b[i]
+		if d < -eps || d > eps {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+// Package transform implements the image augmentations commonly used to
+// boost MNIST model accuracy: random shifts, rotations, scaling, and the
+// elastic distortions described in Simard et al., "Best Practices for
+// Convolutional Neural Networks Applied to Visual Document Analysis".
+package transform
+
+import (
+	"math"
+	"math/rand"
+
+	gomnist "github.com/kuroko1t/GoMNIST"
+)
+
+// Transform maps an image to an augmented version of itself, drawing any
+// randomness it needs from rng.
+type Transform func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage
+
+// Compose returns a Transform that applies ts in order, each operating on
+// the previous one's output.
+func Compose(ts ...Transform) Transform {
+	return func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage {
+		for _, t := range ts {
+			img = t(img, rng)
+		}
+		return img
+	}
+}
+
+// RandomShift returns a Transform that translates the image by a random
+// offset in [-maxPx, maxPx] along each axis, padding with background.
+func RandomShift(maxPx int) Transform {
+	return func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage {
+		dx := rng.Intn(2*maxPx+1) - maxPx
+		dy := rng.Intn(2*maxPx+1) - maxPx
+		out := make(gomnist.RawImage, gomnist.Width*gomnist.Height)
+		for y := 0; y < gomnist.Height; y++ {
+			for x := 0; x < gomnist.Width; x++ {
+				out[y*gomnist.Width+x] = sampleNearest(img, x-dx, y-dy)
+			}
+		}
+		return out
+	}
+}
+
+// RandomRotate returns a Transform that rotates the image about its
+// center by a random angle in [-maxDeg, maxDeg] degrees, sampling the
+// source bilinearly and padding with background.
+func RandomRotate(maxDeg float64) Transform {
+	return func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage {
+		deg := (rng.Float64()*2 - 1) * maxDeg
+		theta := deg * math.Pi / 180
+		sin, cos := math.Sin(theta), math.Cos(theta)
+		cx, cy := float64(gomnist.Width-1)/2, float64(gomnist.Height-1)/2
+		out := make(gomnist.RawImage, gomnist.Width*gomnist.Height)
+		for y := 0; y < gomnist.Height; y++ {
+			for x := 0; x < gomnist.Width; x++ {
+				// Sample the source at the point that rotates onto (x,y),
+				// i.e. apply the inverse rotation.
+				ox, oy := float64(x)-cx, float64(y)-cy
+				sx := cos*ox + sin*oy + cx
+				sy := -sin*ox + cos*oy + cy
+				out[y*gomnist.Width+x] = sampleBilinear(img, sx, sy)
+			}
+		}
+		return out
+	}
+}
+
+// RandomScale returns a Transform that scales the image about its center
+// by a random factor in [min, max], sampling the source bilinearly and
+// padding with background.
+func RandomScale(min, max float64) Transform {
+	return func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage {
+		scale := min + rng.Float64()*(max-min)
+		cx, cy := float64(gomnist.Width-1)/2, float64(gomnist.Height-1)/2
+		out := make(gomnist.RawImage, gomnist.Width*gomnist.Height)
+		for y := 0; y < gomnist.Height; y++ {
+			for x := 0; x < gomnist.Width; x++ {
+				sx := (float64(x)-cx)/scale + cx
+				sy := (float64(y)-cy)/scale + cy
+				out[y*gomnist.Width+x] = sampleBilinear(img, sx, sy)
+			}
+		}
+		return out
+	}
+}
+
+// ElasticDistort returns a Transform implementing the elastic distortion
+// of Simard et al.: two random displacement fields, uniform in [-1, 1],
+// are smoothed with a Gaussian kernel of standard deviation sigma and
+// scaled by alpha, and each output pixel is bilinearly sampled from the
+// source at its displaced coordinate.
+func ElasticDistort(alpha, sigma float64) Transform {
+	return func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage {
+		dx := smooth(randomField(rng), sigma, alpha)
+		dy := smooth(randomField(rng), sigma, alpha)
+		out := make(gomnist.RawImage, gomnist.Width*gomnist.Height)
+		for y := 0; y < gomnist.Height; y++ {
+			for x := 0; x < gomnist.Width; x++ {
+				sx := float64(x) + dx[y*gomnist.Width+x]
+				sy := float64(y) + dy[y*gomnist.Width+x]
+				out[y*gomnist.Width+x] = sampleBilinear(img, sx, sy)
+			}
+		}
+		return out
+	}
+}
+
+// randomField generates a Width*Height field of values uniform in [-1, 1].
+func randomField(rng *rand.Rand) []float64 {
+	field := make([]float64, gomnist.Width*gomnist.Height)
+	for i := range field {
+		field[i] = rng.Float64()*2 - 1
+	}
+	return field
+}
+
+// smooth convolves field with a Gaussian kernel of standard deviation
+// sigma, separably along x and y, then scales the result by alpha.
+func smooth(field []float64, sigma, alpha float64) []float64 {
+	kernel := gaussianKernel(sigma)
+	tmp := convolve1D(field, kernel, true)
+	out := convolve1D(tmp, kernel, false)
+	for i := range out {
+		out[i] *= alpha
+	}
+	return out
+}
+
+// gaussianKernel builds a normalized 1D Gaussian kernel wide enough to
+// cover +/-3 standard deviations.
+func gaussianKernel(sigma float64) []float64 {
+	radius := int(math.Ceil(3 * sigma))
+	if radius < 1 {
+		radius = 1
+	}
+	kernel := make([]float64, 2*radius+1)
+	sum := 0.0
+	for i := -radius; i <= radius; i++ {
+		v := math.Exp(-float64(i*i) / (2 * sigma * sigma))
+		kernel[i+radius] = v
+		sum += v
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
+
+// convolve1D convolves a Width*Height field with kernel along a single
+// axis (horizontal if horizontal is true, vertical otherwise), using
+// zero-padding at the boundary.
+func convolve1D(field []float64, kernel []float64, horizontal bool) []float64 {
+	radius := len(kernel) / 2
+	out := make([]float64, len(field))
+	for y := 0; y < gomnist.Height; y++ {
+		for x := 0; x < gomnist.Width; x++ {
+			var acc float64
+			for k := -radius; k <= radius; k++ {
+				var sx, sy int
+				if horizontal {
+					sx, sy = x+k, y
+				} else {
+					sx, sy = x, y+k
+				}
+				if sx < 0 || sx >= gomnist.Width || sy < 0 || sy >= gomnist.Height {
+					continue
+				}
+				acc += field[sy*gomnist.Width+sx] * kernel[k+radius]
+			}
+			out[y*gomnist.Width+x] = acc
+		}
+	}
+	return out
+}
+
+// sampleNearest returns the pixel at (x, y), or background (0) if it
+// falls outside the image bounds.
+func sampleNearest(img gomnist.RawImage, x, y int) byte {
+	if x < 0 || x >= gomnist.Width || y < 0 || y >= gomnist.Height {
+		return 0
+	}
+	return img[y*gomnist.Width+x]
+}
+
+// sampleBilinear returns the bilinearly interpolated pixel value at
+// fractional coordinates (x, y), treating anything outside the image
+// bounds as background (0).
+func sampleBilinear(img gomnist.RawImage, x, y float64) byte {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	ix0, iy0 := int(x0), int(y0)
+
+	v00 := float64(sampleNearest(img, ix0, iy0))
+	v10 := float64(sampleNearest(img, ix0+1, iy0))
+	v01 := float64(sampleNearest(img, ix0, iy0+1))
+	v11 := float64(sampleNearest(img, ix0+1, iy0+1))
+
+	top := v00*(1-fx) + v10*fx
+	bottom := v01*(1-fx) + v11*fx
+	v := top*(1-fy) + bottom*fy
+	return byte(math.Round(v))
+}
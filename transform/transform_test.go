@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"os"
+	"testing"
+
+	gomnist "github.com/kuroko1t/GoMNIST"
+)
+
+func testImage() gomnist.RawImage {
+	img := make(gomnist.RawImage, gomnist.Width*gomnist.Height)
+	for i := range img {
+		img[i] = byte(i % 256)
+	}
+	return img
+}
+
+func TestSampleNearestOutOfBounds(t *testing.T) {
+	img := testImage()
+	cases := [][2]int{{-1, 0}, {0, -1}, {gomnist.Width, 0}, {0, gomnist.Height}}
+	for _, c := range cases {
+		if got := sampleNearest(img, c[0], c[1]); got != 0 {
+			t.Errorf("sampleNearest(%d,%d) = %d, want 0 (background)", c[0], c[1], got)
+		}
+	}
+}
+
+func TestSampleBilinearAtGridPoints(t *testing.T) {
+	img := testImage()
+	for y := 0; y < gomnist.Height; y += 7 {
+		for x := 0; x < gomnist.Width; x += 7 {
+			want := img[y*gomnist.Width+x]
+			got := sampleBilinear(img, float64(x), float64(y))
+			if got != want {
+				t.Errorf("sampleBilinear(%d,%d) = %d, want %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestSampleBilinearMidpoint(t *testing.T) {
+	img := make(gomnist.RawImage, gomnist.Width*gomnist.Height)
+	img[0] = 0
+	img[1] = 100
+	got := sampleBilinear(img, 0.5, 0)
+	if got != 50 {
+		t.Errorf("sampleBilinear(0.5,0) = %d, want 50 (midpoint of 0 and 100)", got)
+	}
+}
+
+func TestGaussianKernelNormalized(t *testing.T) {
+	for _, sigma := range []float64{0.5, 1, 2, 4} {
+		k := gaussianKernel(sigma)
+		var sum float64
+		for _, v := range k {
+			sum += v
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("sigma=%v: kernel sums to %v, want 1", sigma, sum)
+		}
+		if len(k)%2 != 1 {
+			t.Errorf("sigma=%v: kernel length %d is not odd", sigma, len(k))
+		}
+	}
+}
+
+func TestConvolve1DZeroPadding(t *testing.T) {
+	field := make([]float64, gomnist.Width*gomnist.Height)
+	field[0] = 1 // top-left corner
+	kernel := []float64{0.25, 0.5, 0.25}
+	out := convolve1D(field, kernel, true)
+	// The corner's left neighbor lies outside the image and its right
+	// neighbor is zero, so with zero-padding only the center tap contributes.
+	if out[0] != 0.5 {
+		t.Errorf("out[0] = %v, want 0.5 (zero-padded boundary)", out[0])
+	}
+}
+
+func TestRandomShiftZeroIsNoop(t *testing.T) {
+	img := testImage()
+	rng := rand.New(rand.NewSource(1))
+	out := RandomShift(0)(img, rng)
+	for i := range img {
+		if out[i] != img[i] {
+			t.Fatalf("pixel %d: got %d, want %d (RandomShift(0) should be a no-op)", i, out[i], img[i])
+		}
+	}
+}
+
+func TestRandomRotateZeroIsNoop(t *testing.T) {
+	img := testImage()
+	rng := rand.New(rand.NewSource(1))
+	out := RandomRotate(0)(img, rng)
+	for i := range img {
+		if out[i] != img[i] {
+			t.Fatalf("pixel %d: got %d, want %d (RandomRotate(0) should be a no-op)", i, out[i], img[i])
+		}
+	}
+}
+
+func TestRandomScaleUnityIsNoop(t *testing.T) {
+	img := testImage()
+	rng := rand.New(rand.NewSource(1))
+	out := RandomScale(1, 1)(img, rng)
+	for i := range img {
+		if out[i] != img[i] {
+			t.Fatalf("pixel %d: got %d, want %d (RandomScale(1,1) should be a no-op)", i, out[i], img[i])
+		}
+	}
+}
+
+func TestTransformsPreserveDimensions(t *testing.T) {
+	img := testImage()
+	rng := rand.New(rand.NewSource(1))
+	transforms := map[string]Transform{
+		"shift":   RandomShift(2),
+		"rotate":  RandomRotate(15),
+		"scale":   RandomScale(0.9, 1.1),
+		"elastic": ElasticDistort(8, 4),
+	}
+	for name, tr := range transforms {
+		out := tr(img, rng)
+		if len(out) != gomnist.Width*gomnist.Height {
+			t.Errorf("%s: len(out) = %d, want %d", name, len(out), gomnist.Width*gomnist.Height)
+		}
+	}
+}
+
+func TestComposeAppliesInOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Transform {
+		return func(img gomnist.RawImage, rng *rand.Rand) gomnist.RawImage {
+			order = append(order, name)
+			return img
+		}
+	}
+	composed := Compose(mark("a"), mark("b"), mark("c"))
+	composed(testImage(), rand.New(rand.NewSource(1)))
+	want := []string{"a", "b", "c"}
+	if len(order) != len(want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRandomRotatePNGRoundTrip(t *testing.T) {
+	img := testImage()
+	rng := rand.New(rand.NewSource(1))
+	out := RandomRotate(10)(img, rng)
+
+	var buf bytes.Buffer
+	if err := out.EncodePNG(&buf); err != nil {
+		t.Fatalf("EncodePNG: %v", err)
+	}
+
+	path := t.TempDir() + "/out.png"
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	roundTripped, err := gomnist.LoadPNGAsRawImage(path)
+	if err != nil {
+		t.Fatalf("LoadPNGAsRawImage: %v", err)
+	}
+	if len(roundTripped) != len(out) {
+		t.Fatalf("round-tripped image has len %d, want %d", len(roundTripped), len(out))
+	}
+	for i := range out {
+		if roundTripped[i] != out[i] {
+			t.Fatalf("pixel %d: got %d, want %d", i, roundTripped[i], out[i])
+		}
+	}
+}